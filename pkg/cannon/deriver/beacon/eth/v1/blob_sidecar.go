@@ -0,0 +1,384 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	backoff "github.com/cenkalti/backoff/v4"
+	"github.com/ethpandaops/xatu/pkg/cannon/deriver/beacon/eth/v2"
+	"github.com/ethpandaops/xatu/pkg/cannon/ethereum"
+	"github.com/ethpandaops/xatu/pkg/cannon/iterator"
+	xatuethv1 "github.com/ethpandaops/xatu/pkg/proto/eth/v1"
+	"github.com/ethpandaops/xatu/pkg/proto/xatu"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	BlobSidecarDeriverName = xatu.CannonType_BEACON_API_ETH_V1_BEACON_BLOB_SIDECAR
+)
+
+type BlobSidecarDeriverConfig struct {
+	Enabled     bool    `yaml:"enabled" default:"true"`
+	HeadSlotLag *uint64 `yaml:"headSlotLag" default:"1"`
+	// IncludeBlobBytes controls whether the raw blob (~128KiB) is attached
+	// to the emitted event, or just its commitment/proof.
+	IncludeBlobBytes bool `yaml:"includeBlobBytes" default:"false"`
+	// EngineAPI, if enabled, is tried first for fetching blobs via
+	// engine_getBlobsV1 before falling back to the beacon node. This
+	// dramatically reduces beacon node load during live tailing, since
+	// blobs the local EL already saw via gossip are returned directly.
+	EngineAPI *ethereum.EngineClientConfig `yaml:"engineApi"`
+}
+
+type BlobSidecarDeriver struct {
+	log                 logrus.FieldLogger
+	cfg                 *BlobSidecarDeriverConfig
+	iterator            iterator.Iterator
+	onEventCallbacks    []func(ctx context.Context, event *xatu.DecoratedEvent) error
+	onLocationCallbacks []func(ctx context.Context, loc uint64) error
+	beacon              *ethereum.BeaconNode
+	// engine is the optional engine-API client used to fetch blobs directly
+	// from the execution client's blobpool before falling back to the CL.
+	// Nil when no EL endpoint is configured.
+	engine     *ethereum.EngineClient
+	clientMeta *xatu.ClientMeta
+}
+
+func NewBlobSidecarDeriver(log logrus.FieldLogger, config *BlobSidecarDeriverConfig, iter iterator.Iterator, beacon *ethereum.BeaconNode, engine *ethereum.EngineClient, clientMeta *xatu.ClientMeta) *BlobSidecarDeriver {
+	return &BlobSidecarDeriver{
+		log:        log.WithField("module", "cannon/event/beacon/eth/v1/blob_sidecar"),
+		cfg:        config,
+		iterator:   iter,
+		beacon:     beacon,
+		engine:     engine,
+		clientMeta: clientMeta,
+	}
+}
+
+func (b *BlobSidecarDeriver) CannonType() xatu.CannonType {
+	return BlobSidecarDeriverName
+}
+
+func (b *BlobSidecarDeriver) Name() string {
+	return BlobSidecarDeriverName.String()
+}
+
+func (b *BlobSidecarDeriver) OnEventDerived(ctx context.Context, fn func(ctx context.Context, event *xatu.DecoratedEvent) error) {
+	b.onEventCallbacks = append(b.onEventCallbacks, fn)
+}
+
+func (b *BlobSidecarDeriver) OnLocationUpdated(ctx context.Context, fn func(ctx context.Context, location uint64) error) {
+	b.onLocationCallbacks = append(b.onLocationCallbacks, fn)
+}
+
+func (b *BlobSidecarDeriver) Start(ctx context.Context) error {
+	if !b.cfg.Enabled {
+		b.log.Info("Blob sidecar deriver disabled")
+
+		return nil
+	}
+
+	b.log.Info("Blob sidecar deriver enabled")
+
+	// Start our main loop
+	go b.run(ctx)
+
+	return nil
+}
+
+func (b *BlobSidecarDeriver) Stop(ctx context.Context) error {
+	return nil
+}
+
+func (b *BlobSidecarDeriver) run(ctx context.Context) {
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxInterval = 1 * time.Minute
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			operation := func() error {
+				time.Sleep(100 * time.Millisecond)
+
+				if err := b.beacon.Synced(ctx); err != nil {
+					return err
+				}
+
+				// Get the next slot
+				location, err := b.iterator.Next(ctx)
+				if err != nil {
+					return err
+				}
+
+				for _, fn := range b.onLocationCallbacks {
+					if errr := fn(ctx, location.GetEthV1BeaconBlobSidecar().GetSlot()); errr != nil {
+						b.log.WithError(errr).Error("Failed to send location")
+					}
+				}
+
+				// Process the slot
+				events, err := b.processSlot(ctx, phase0.Slot(location.GetEthV1BeaconBlobSidecar().GetSlot()), location.GetReorged())
+				if err != nil {
+					b.log.WithError(err).Error("Failed to process slot")
+
+					return err
+				}
+
+				// Send the events
+				for _, event := range events {
+					for _, fn := range b.onEventCallbacks {
+						if err := fn(ctx, event); err != nil {
+							b.log.WithError(err).Error("Failed to send event")
+						}
+					}
+				}
+
+				// Update our location
+				if err := b.iterator.UpdateLocation(ctx, location); err != nil {
+					return err
+				}
+
+				bo.Reset()
+
+				return nil
+			}
+
+			if err := backoff.RetryNotify(operation, bo, func(err error, timer time.Duration) {
+				b.log.WithError(err).WithField("next_attempt", timer).Warn("Failed to process")
+			}); err != nil {
+				b.log.WithError(err).Warn("Failed to process")
+			}
+		}
+	}
+}
+
+func (b *BlobSidecarDeriver) processSlot(ctx context.Context, slot phase0.Slot, reorged bool) ([]*xatu.DecoratedEvent, error) {
+	if b.cfg.HeadSlotLag != nil {
+		wallclockSlot, _ := b.beacon.Metadata().Wallclock().Now()
+
+		if uint64(slot)+*b.cfg.HeadSlotLag > wallclockSlot.Number() {
+			b.log.WithFields(logrus.Fields{
+				"slot":          slot,
+				"head_slot_lag": *b.cfg.HeadSlotLag,
+			}).Debug("Slot is within headSlotLag of the chain head, skipping until it's more likely to be canonical")
+
+			return []*xatu.DecoratedEvent{}, nil
+		}
+	}
+
+	block, err := b.beacon.GetBeaconBlock(ctx, xatuethv1.SlotAsString(slot))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get beacon block for slot %d", slot)
+	}
+
+	if block == nil {
+		return []*xatu.DecoratedEvent{}, nil
+	}
+
+	if block.Version < spec.DataVersionDeneb {
+		// Blobs don't exist before Deneb.
+		return []*xatu.DecoratedEvent{}, nil
+	}
+
+	blockIdentifier, err := v2.GetBlockIdentifier(block, b.beacon.Metadata().Wallclock())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block identifier for slot %d", slot)
+	}
+
+	sidecars, err := b.getBlobSidecars(ctx, block, blockIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []*xatu.DecoratedEvent{}
+
+	for _, sidecar := range sidecars {
+		if sidecar == nil {
+			// Neither the engine API nor the beacon node had a sidecar for
+			// this index (e.g. the EL didn't have it and the CL's response
+			// omitted it too). Don't emit a garbage, all-zero event for it.
+			continue
+		}
+
+		event, err := b.createEvent(ctx, sidecar, blockIdentifier, reorged)
+		if err != nil {
+			b.log.WithError(err).Error("Failed to create event")
+
+			return nil, errors.Wrapf(err, "failed to create event for blob sidecar %d", sidecar.GetIndex())
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (b *BlobSidecarDeriver) getBlobSidecars(ctx context.Context, block *spec.VersionedSignedBeaconBlock, identifier *xatu.BlockIdentifier) ([]*xatuethv1.BlobSidecar, error) {
+	var commitments []deneb.KZGCommitment
+
+	switch block.Version {
+	case spec.DataVersionPhase0, spec.DataVersionAltair, spec.DataVersionBellatrix, spec.DataVersionCapella:
+		return []*xatuethv1.BlobSidecar{}, nil
+	case spec.DataVersionDeneb:
+		commitments = block.Deneb.Message.Body.BlobKZGCommitments
+	case spec.DataVersionElectra:
+		commitments = block.Electra.Message.Body.BlobKZGCommitments
+	default:
+		// Future forks also carry blobs until EIP-7594's PeerDAS changes the
+		// data availability layer entirely - treat an unrecognised version
+		// the same as Electra's ancestor rather than hard-erroring forever.
+		return nil, fmt.Errorf("unsupported block version: %s", block.Version.String())
+	}
+
+	sidecars, missing, err := b.getBlobSidecarsFromEngine(ctx, commitments, identifier)
+	if err != nil {
+		b.log.WithError(err).Warn("Failed to get blobs from engine API, falling back to beacon node")
+
+		missing = commitments
+		sidecars = make([]*xatuethv1.BlobSidecar, len(commitments))
+	}
+
+	if len(missing) == 0 {
+		return sidecars, nil
+	}
+
+	raw, err := b.beacon.GetBlobSidecars(ctx, xatuethv1.SlotAsString(phase0.Slot(identifier.GetSlot())))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get blob sidecars for slot %d", identifier.GetSlot())
+	}
+
+	for _, sidecar := range raw {
+		if int(sidecar.Index) >= len(sidecars) {
+			b.log.WithField("index", sidecar.Index).Warn("Beacon node returned blob sidecar with out-of-range index, ignoring")
+
+			continue
+		}
+
+		if sidecars[sidecar.Index] != nil {
+			continue
+		}
+
+		sidecars[sidecar.Index] = b.toProto(sidecar, identifier)
+		blobSidecarSource.WithLabelValues("cl").Inc()
+	}
+
+	return sidecars, nil
+}
+
+// getBlobSidecarsFromEngine fetches blobs via `engine_getBlobsV1` from the
+// configured execution client, if any. It returns a slice indexed by blob
+// index, with entries left nil (and returned in `missing`) for any blob the
+// EL doesn't have, so the caller can fall back to the CL for just those.
+func (b *BlobSidecarDeriver) getBlobSidecarsFromEngine(ctx context.Context, commitments []deneb.KZGCommitment, identifier *xatu.BlockIdentifier) ([]*xatuethv1.BlobSidecar, []deneb.KZGCommitment, error) {
+	sidecars := make([]*xatuethv1.BlobSidecar, len(commitments))
+
+	if b.engine == nil {
+		return sidecars, commitments, nil
+	}
+
+	versionedHashes := ethereum.VersionedHashesFromCommitments(commitments)
+
+	blobs, err := b.engine.GetBlobsV1(ctx, versionedHashes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to call engine_getBlobsV1")
+	}
+
+	if len(blobs) != len(commitments) {
+		b.log.WithFields(logrus.Fields{
+			"requested": len(commitments),
+			"returned":  len(blobs),
+		}).Warn("engine_getBlobsV1 returned a different number of blobs than requested, falling back to beacon node for all of them")
+
+		return make([]*xatuethv1.BlobSidecar, len(commitments)), commitments, nil
+	}
+
+	missing := []deneb.KZGCommitment{}
+
+	for i, blob := range blobs {
+		if blob == nil {
+			missing = append(missing, commitments[i])
+
+			continue
+		}
+
+		pb := &xatuethv1.BlobSidecar{
+			Index:         uint64(i),
+			KzgCommitment: commitments[i].String(),
+			KzgProof:      blob.Proof.String(),
+			Slot:          identifier.GetSlot(),
+			BlockRoot:     identifier.GetRoot(),
+			ParentRoot:    identifier.GetParentRoot(),
+			ProposerIndex: identifier.GetProposerIndex(),
+		}
+
+		if b.cfg.IncludeBlobBytes {
+			pb.Blob = blob.Blob.String()
+		}
+
+		sidecars[i] = pb
+
+		blobSidecarSource.WithLabelValues("el").Inc()
+	}
+
+	return sidecars, missing, nil
+}
+
+func (b *BlobSidecarDeriver) toProto(sidecar *deneb.BlobSidecar, identifier *xatu.BlockIdentifier) *xatuethv1.BlobSidecar {
+	pb := &xatuethv1.BlobSidecar{
+		Index:         uint64(sidecar.Index),
+		KzgCommitment: sidecar.KZGCommitment.String(),
+		KzgProof:      sidecar.KZGProof.String(),
+		Slot:          identifier.GetSlot(),
+		BlockRoot:     identifier.GetRoot(),
+		ParentRoot:    identifier.GetParentRoot(),
+		ProposerIndex: identifier.GetProposerIndex(),
+	}
+
+	if b.cfg.IncludeBlobBytes {
+		pb.Blob = sidecar.Blob.String()
+	}
+
+	return pb
+}
+
+func (b *BlobSidecarDeriver) createEvent(ctx context.Context, sidecar *xatuethv1.BlobSidecar, identifier *xatu.BlockIdentifier, reorged bool) (*xatu.DecoratedEvent, error) {
+	// Make a clone of the metadata
+	metadata, ok := proto.Clone(b.clientMeta).(*xatu.ClientMeta)
+	if !ok {
+		return nil, errors.New("failed to clone client metadata")
+	}
+
+	decoratedEvent := &xatu.DecoratedEvent{
+		Event: &xatu.Event{
+			Name:     xatu.Event_BEACON_API_ETH_V1_BEACON_BLOB_SIDECAR,
+			DateTime: timestamppb.New(time.Now()),
+			Id:       uuid.New().String(),
+			Reorged:  reorged,
+		},
+		Meta: &xatu.Meta{
+			Client: metadata,
+		},
+		Data: &xatu.DecoratedEvent_EthV1BeaconBlobSidecar{
+			EthV1BeaconBlobSidecar: sidecar,
+		},
+	}
+
+	decoratedEvent.Meta.Client.AdditionalData = &xatu.ClientMeta_EthV1BeaconBlobSidecar{
+		EthV1BeaconBlobSidecar: &xatu.ClientMeta_AdditionalEthV1BeaconBlobSidecarData{
+			Block: identifier,
+		},
+	}
+
+	return decoratedEvent, nil
+}
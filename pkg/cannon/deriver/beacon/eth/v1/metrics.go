@@ -0,0 +1,11 @@
+package v1
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var blobSidecarSource = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "xatu_cannon_blob_sidecar_source_count",
+	Help: "Number of blob sidecars served, by source (el or cl)",
+}, []string{"source"})
@@ -17,11 +17,17 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+var tracer = otel.Tracer("github.com/ethpandaops/xatu/pkg/cannon/deriver/beacon/eth/v2")
+
 const (
 	BLSToExecutionChangeDeriverName = xatu.CannonType_BEACON_API_ETH_V2_BEACON_BLOCK_BLS_TO_EXECUTION_CHANGE
 )
@@ -34,14 +40,14 @@ type BLSToExecutionChangeDeriverConfig struct {
 type BLSToExecutionChangeDeriver struct {
 	log                 logrus.FieldLogger
 	cfg                 *BLSToExecutionChangeDeriverConfig
-	iterator            *iterator.SlotIterator
+	iterator            iterator.Iterator
 	onEventCallbacks    []func(ctx context.Context, event *xatu.DecoratedEvent) error
 	onLocationCallbacks []func(ctx context.Context, loc uint64) error
 	beacon              *ethereum.BeaconNode
 	clientMeta          *xatu.ClientMeta
 }
 
-func NewBLSToExecutionChangeDeriver(log logrus.FieldLogger, config *BLSToExecutionChangeDeriverConfig, iter *iterator.SlotIterator, beacon *ethereum.BeaconNode, clientMeta *xatu.ClientMeta) *BLSToExecutionChangeDeriver {
+func NewBLSToExecutionChangeDeriver(log logrus.FieldLogger, config *BLSToExecutionChangeDeriverConfig, iter iterator.Iterator, beacon *ethereum.BeaconNode, clientMeta *xatu.ClientMeta) *BLSToExecutionChangeDeriver {
 	return &BLSToExecutionChangeDeriver{
 		log:        log.WithField("module", "cannon/event/beacon/eth/v2/bls_to_execution_change"),
 		cfg:        config,
@@ -96,43 +102,72 @@ func (b *BLSToExecutionChangeDeriver) run(ctx context.Context) {
 			return
 		default:
 			operation := func() error {
+				ctx, span := tracer.Start(ctx, "cannon.deriver.iteration")
+				defer span.End()
+
 				time.Sleep(100 * time.Millisecond)
 
 				if err := b.beacon.Synced(ctx); err != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+
 					return err
 				}
 
 				// Get the next slot
-				location, err := b.iterator.Next(ctx)
+				locationCtx, locationSpan := tracer.Start(ctx, "iterator.Next")
+				location, err := b.iterator.Next(locationCtx)
+				locationSpan.End()
+
 				if err != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+
 					return err
 				}
 
+				slot := location.GetEthV2BeaconBlockBlsToExecutionChange().GetSlot()
+				reorged := location.GetReorged()
+				span.SetAttributes(attribute.Int64("slot", int64(slot)), attribute.Bool("reorged", reorged))
+
 				for _, fn := range b.onLocationCallbacks {
-					if errr := fn(ctx, location.GetEthV2BeaconBlockBlsToExecutionChange().GetSlot()); errr != nil {
+					if errr := fn(ctx, slot); errr != nil {
 						b.log.WithError(errr).Error("Failed to send location")
 					}
 				}
 
 				// Process the slot
-				events, err := b.processSlot(ctx, phase0.Slot(location.GetEthV2BeaconBlockBlsToExecutionChange().GetSlot()))
+				events, err := b.processSlot(ctx, phase0.Slot(slot), reorged)
 				if err != nil {
 					b.log.WithError(err).Error("Failed to process slot")
 
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+
 					return err
 				}
 
 				// Send the events
 				for _, event := range events {
 					for _, fn := range b.onEventCallbacks {
-						if err := fn(ctx, event); err != nil {
+						callbackCtx, callbackSpan := tracer.Start(ctx, "onEventCallback")
+
+						if err := fn(callbackCtx, event); err != nil {
 							b.log.WithError(err).Error("Failed to send event")
+
+							callbackSpan.RecordError(err)
+							callbackSpan.SetStatus(codes.Error, err.Error())
 						}
+
+						callbackSpan.End()
 					}
 				}
 
 				// Update our location
 				if err := b.iterator.UpdateLocation(ctx, location); err != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+
 					return err
 				}
 
@@ -150,10 +185,19 @@ func (b *BLSToExecutionChangeDeriver) run(ctx context.Context) {
 	}
 }
 
-func (b *BLSToExecutionChangeDeriver) processSlot(ctx context.Context, slot phase0.Slot) ([]*xatu.DecoratedEvent, error) {
+func (b *BLSToExecutionChangeDeriver) processSlot(ctx context.Context, slot phase0.Slot, reorged bool) ([]*xatu.DecoratedEvent, error) {
+	ctx, span := tracer.Start(ctx, "processSlot", trace.WithAttributes(attribute.Int64("slot", int64(slot))))
+	defer span.End()
+
 	// Get the block
-	block, err := b.beacon.GetBeaconBlock(ctx, xatuethv1.SlotAsString(slot))
+	blockCtx, blockSpan := tracer.Start(ctx, "beacon.GetBeaconBlock")
+	block, err := b.beacon.GetBeaconBlock(blockCtx, xatuethv1.SlotAsString(slot))
+	blockSpan.End()
+
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
 		return nil, errors.Wrapf(err, "failed to get beacon block for slot %d", slot)
 	}
 
@@ -161,11 +205,18 @@ func (b *BLSToExecutionChangeDeriver) processSlot(ctx context.Context, slot phas
 		return []*xatu.DecoratedEvent{}, nil
 	}
 
+	span.SetAttributes(attribute.String("version", block.Version.String()))
+
 	blockIdentifier, err := GetBlockIdentifier(block, b.beacon.Metadata().Wallclock())
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
 		return nil, errors.Wrapf(err, "failed to get block identifier for slot %d", slot)
 	}
 
+	span.SetAttributes(attribute.String("block_root", blockIdentifier.GetRoot()))
+
 	events := []*xatu.DecoratedEvent{}
 
 	changes, err := b.getBLSToExecutionChanges(ctx, block)
@@ -174,7 +225,7 @@ func (b *BLSToExecutionChangeDeriver) processSlot(ctx context.Context, slot phas
 	}
 
 	for _, change := range changes {
-		event, err := b.createEvent(ctx, change, blockIdentifier)
+		event, err := b.createEvent(ctx, change, blockIdentifier, reorged)
 		if err != nil {
 			b.log.WithError(err).Error("Failed to create event")
 
@@ -215,7 +266,7 @@ func (b *BLSToExecutionChangeDeriver) getBLSToExecutionChanges(ctx context.Conte
 	return changes, nil
 }
 
-func (b *BLSToExecutionChangeDeriver) createEvent(ctx context.Context, change *xatuethv2.SignedBLSToExecutionChangeV2, identifier *xatu.BlockIdentifier) (*xatu.DecoratedEvent, error) {
+func (b *BLSToExecutionChangeDeriver) createEvent(ctx context.Context, change *xatuethv2.SignedBLSToExecutionChangeV2, identifier *xatu.BlockIdentifier, reorged bool) (*xatu.DecoratedEvent, error) {
 	// Make a clone of the metadata
 	metadata, ok := proto.Clone(b.clientMeta).(*xatu.ClientMeta)
 	if !ok {
@@ -227,6 +278,7 @@ func (b *BLSToExecutionChangeDeriver) createEvent(ctx context.Context, change *x
 			Name:     xatu.Event_BEACON_API_ETH_V2_BEACON_BLOCK_BLS_TO_EXECUTION_CHANGE,
 			DateTime: timestamppb.New(time.Now()),
 			Id:       uuid.New().String(),
+			Reorged:  reorged,
 		},
 		Meta: &xatu.Meta{
 			Client: metadata,
@@ -0,0 +1,32 @@
+package ethereum
+
+import (
+	"context"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/pkg/errors"
+)
+
+// GetBlobSidecars fetches the blob sidecars for the given block from the
+// beacon node's `/eth/v1/beacon/blob_sidecars/{block_id}` endpoint.
+func (b *BeaconNode) GetBlobSidecars(ctx context.Context, blockID string) ([]*deneb.BlobSidecar, error) {
+	provider, isProvider := b.node.(eth2client.BlobSidecarsProvider)
+	if !isProvider {
+		return nil, errors.New("beacon node does not support fetching blob sidecars")
+	}
+
+	rsp, err := provider.BlobSidecars(ctx, &api.BlobSidecarsOpts{
+		Block: blockID,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch blob sidecars for block %s", blockID)
+	}
+
+	if rsp == nil {
+		return []*deneb.BlobSidecar{}, nil
+	}
+
+	return rsp.Data, nil
+}
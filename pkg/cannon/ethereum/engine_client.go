@@ -0,0 +1,143 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+// EngineClientConfig configures the JSON-RPC engine API client used to fetch
+// blobs directly from an execution client's mempool/blobpool, as an
+// alternative to pulling them from the beacon node.
+type EngineClientConfig struct {
+	Enabled bool `yaml:"enabled" default:"false"`
+	// Addr is the engine API endpoint, e.g. "http://localhost:8551".
+	Addr string `yaml:"addr"`
+	// JWTSecretFile is a path to the hex-encoded JWT secret shared with the
+	// execution client, as required by the engine API auth spec.
+	JWTSecretFile string `yaml:"jwtSecretFile"`
+}
+
+// EngineClient is a minimal JSON-RPC client for the subset of the engine
+// API needed to fetch blobs (`engine_getBlobsV1`) directly from an
+// execution client, authenticated with the shared JWT secret.
+type EngineClient struct {
+	cfg       *EngineClientConfig
+	jwtSecret []byte
+	http      *http.Client
+}
+
+// BlobAndProofV1 mirrors the execution-apis `BlobAndProofV1` engine API
+// type: the blob body plus its KZG proof. A nil entry in the
+// engine_getBlobsV1 response means the EL doesn't have that blob.
+type BlobAndProofV1 struct {
+	Blob  deneb.Blob     `json:"blob"`
+	Proof deneb.KZGProof `json:"proof"`
+}
+
+func NewEngineClient(cfg *EngineClientConfig) (*EngineClient, error) {
+	secretHex, err := os.ReadFile(cfg.JWTSecretFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read engine api jwt secret")
+	}
+
+	secret, err := hex.DecodeString(string(bytes.TrimSpace(secretHex)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode engine api jwt secret")
+	}
+
+	return &EngineClient{
+		cfg:       cfg,
+		jwtSecret: secret,
+		http:      &http.Client{Timeout: 5 * time.Second},
+	}, nil
+}
+
+// VersionedHashesFromCommitments derives the versioned hashes engine_getBlobsV1
+// expects from a Deneb+ block's blob KZG commitments: sha256(commitment)
+// with its first byte replaced by the 0x01 "blob" version prefix.
+func VersionedHashesFromCommitments(commitments []deneb.KZGCommitment) []string {
+	hashes := make([]string, len(commitments))
+
+	for i, commitment := range commitments {
+		sum := sha256.Sum256(commitment[:])
+		sum[0] = 0x01
+
+		hashes[i] = "0x" + hex.EncodeToString(sum[:])
+	}
+
+	return hashes
+}
+
+// GetBlobsV1 calls `engine_getBlobsV1` with the given versioned hashes. The
+// returned slice is the same length as versionedHashes, with a nil entry
+// wherever the execution client doesn't have that blob (e.g. it was never
+// gossiped to it, or it's already been pruned from the mempool).
+func (e *EngineClient) GetBlobsV1(ctx context.Context, versionedHashes []string) ([]*BlobAndProofV1, error) {
+	token, err := e.authToken()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create engine api auth token")
+	}
+
+	reqBody, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "engine_getBlobsV1",
+		"params":  []interface{}{versionedHashes},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal engine_getBlobsV1 request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.Addr, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create engine_getBlobsV1 request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	rsp, err := e.http.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call engine_getBlobsV1")
+	}
+	defer rsp.Body.Close()
+
+	var result struct {
+		Result []*BlobAndProofV1 `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	if err := json.NewDecoder(rsp.Body).Decode(&result); err != nil {
+		return nil, errors.Wrap(err, "failed to decode engine_getBlobsV1 response")
+	}
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("engine_getBlobsV1 failed: %s (code %d)", result.Error.Message, result.Error.Code)
+	}
+
+	return result.Result, nil
+}
+
+func (e *EngineClient) authToken() (string, error) {
+	claims := jwt.RegisteredClaims{
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return token.SignedString(e.jwtSecret)
+}
@@ -0,0 +1,204 @@
+package ethereum
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// CheckpointStore persists the last-seen head slot/root and finalized epoch
+// so a restarting cannon can catch up on any reorg that happened while it
+// was down, instead of assuming everything it previously fetched is still
+// canonical.
+type CheckpointStore interface {
+	LoadHeadCheckpoint(ctx context.Context) (head phase0.Slot, headRoot phase0.Root, finalizedEpoch phase0.Epoch, err error)
+	SaveHeadCheckpoint(ctx context.Context, head phase0.Slot, headRoot phase0.Root, finalizedEpoch phase0.Epoch) error
+}
+
+// ReorgDetector subscribes to the beacon node's `head` and `chain_reorg` SSE
+// topics and notifies callbacks when a reorg is observed, so that cannon
+// iterators can re-derive the slots that are no longer canonical instead of
+// treating a previously-fetched block as final forever.
+type ReorgDetector struct {
+	log         logrus.FieldLogger
+	beacon      *BeaconNode
+	checkpoints CheckpointStore
+
+	mu                 sync.Mutex
+	lastHead           phase0.Slot
+	lastHeadRoot       phase0.Root
+	lastFinalizedEpoch phase0.Epoch
+
+	onReorgCallbacks []func(ctx context.Context, startSlot, endSlot phase0.Slot) error
+}
+
+func NewReorgDetector(log logrus.FieldLogger, beacon *BeaconNode, checkpoints CheckpointStore) *ReorgDetector {
+	return &ReorgDetector{
+		log:         log.WithField("module", "cannon/ethereum/reorg_detector"),
+		beacon:      beacon,
+		checkpoints: checkpoints,
+	}
+}
+
+// OnReorg registers a callback that's invoked with the (inclusive) slot range
+// that was reorged out, in ascending order, whenever a reorg is observed.
+func (r *ReorgDetector) OnReorg(fn func(ctx context.Context, startSlot, endSlot phase0.Slot) error) {
+	r.onReorgCallbacks = append(r.onReorgCallbacks, fn)
+}
+
+// LastHead returns the last-seen head slot and root, and the last-seen
+// finalized epoch, so a restarting cannon can persist them and catch up on
+// any reorg that happened while it was down.
+func (r *ReorgDetector) LastHead() (phase0.Slot, phase0.Root, phase0.Epoch) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.lastHead, r.lastHeadRoot, r.lastFinalizedEpoch
+}
+
+func (r *ReorgDetector) Start(ctx context.Context) error {
+	if r.checkpoints != nil {
+		head, headRoot, finalizedEpoch, err := r.checkpoints.LoadHeadCheckpoint(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to load head checkpoint")
+		}
+
+		r.mu.Lock()
+		r.lastHead, r.lastHeadRoot, r.lastFinalizedEpoch = head, headRoot, finalizedEpoch
+		r.mu.Unlock()
+
+		if err := r.catchUp(ctx, head, headRoot); err != nil {
+			return errors.Wrap(err, "failed to catch up on reorgs missed while stopped")
+		}
+	}
+
+	provider, isProvider := r.beacon.node.(eth2client.EventsProvider)
+	if !isProvider {
+		return errors.New("beacon node does not support streaming events")
+	}
+
+	return provider.Events(ctx, []string{"head", "chain_reorg", "finalized_checkpoint"}, r.handleEvent)
+}
+
+// catchUp compares the persisted head against the canonical chain, so a
+// reorg that happened entirely while this process was down - and so was
+// never observed as a `chain_reorg` event - still triggers re-derivation.
+// If the block that's canonical at the persisted head slot no longer
+// matches the persisted root, everything from that slot up to the current
+// canonical head is marked dirty.
+func (r *ReorgDetector) catchUp(ctx context.Context, head phase0.Slot, headRoot phase0.Root) error {
+	if head == 0 && headRoot == (phase0.Root{}) {
+		// No prior checkpoint, nothing to catch up on.
+		return nil
+	}
+
+	provider, isProvider := r.beacon.node.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return errors.New("beacon node does not support fetching beacon block headers")
+	}
+
+	canonical, err := provider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{Block: strconv.FormatUint(uint64(head), 10)})
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch canonical block header for slot %d", head)
+	}
+
+	if canonical.Data != nil && canonical.Data.Root == headRoot {
+		// Still canonical, nothing was reorged while we were down.
+		return nil
+	}
+
+	currentHead, err := provider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{Block: "head"})
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch current head block header")
+	}
+
+	endSlot := head
+	if currentHead.Data != nil && currentHead.Data.Header.Message.Slot > endSlot {
+		endSlot = currentHead.Data.Header.Message.Slot
+	}
+
+	r.log.WithFields(logrus.Fields{
+		"persisted_head_slot": head,
+		"persisted_head_root": headRoot.String(),
+		"end_slot":            endSlot,
+	}).Info("Persisted head is no longer canonical, catching up on reorg that happened while stopped")
+
+	for _, fn := range r.onReorgCallbacks {
+		if err := fn(ctx, head, endSlot); err != nil {
+			r.log.WithError(err).Error("Failed to handle reorg")
+		}
+	}
+
+	return nil
+}
+
+func (r *ReorgDetector) persistCheckpoint() {
+	if r.checkpoints == nil {
+		return
+	}
+
+	head, headRoot, finalizedEpoch := r.LastHead()
+
+	if err := r.checkpoints.SaveHeadCheckpoint(context.Background(), head, headRoot, finalizedEpoch); err != nil {
+		r.log.WithError(err).Warn("Failed to persist head checkpoint")
+	}
+}
+
+func (r *ReorgDetector) handleEvent(event *v1.Event) {
+	switch event.Topic {
+	case "head":
+		data, ok := event.Data.(*v1.HeadEvent)
+		if !ok {
+			return
+		}
+
+		r.mu.Lock()
+		r.lastHead = data.Slot
+		r.lastHeadRoot = data.Block
+		r.mu.Unlock()
+
+		r.persistCheckpoint()
+	case "finalized_checkpoint":
+		data, ok := event.Data.(*v1.FinalizedCheckpointEvent)
+		if !ok {
+			return
+		}
+
+		r.mu.Lock()
+		r.lastFinalizedEpoch = data.Epoch
+		r.mu.Unlock()
+
+		r.persistCheckpoint()
+	case "chain_reorg":
+		data, ok := event.Data.(*v1.ChainReorgEvent)
+		if !ok {
+			return
+		}
+
+		var startSlot phase0.Slot
+
+		if depth := phase0.Slot(data.Depth); depth < data.Slot {
+			startSlot = data.Slot - depth
+		}
+
+		endSlot := data.Slot
+
+		r.log.WithFields(logrus.Fields{
+			"slot":  data.Slot,
+			"depth": data.Depth,
+		}).Info("Observed chain reorg")
+
+		for _, fn := range r.onReorgCallbacks {
+			if err := fn(context.Background(), startSlot, endSlot); err != nil {
+				r.log.WithError(err).Error("Failed to handle reorg")
+			}
+		}
+	}
+}
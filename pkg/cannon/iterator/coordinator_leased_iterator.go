@@ -0,0 +1,208 @@
+package iterator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/xatu/pkg/observability/tracing"
+	"github.com/ethpandaops/xatu/pkg/proto/xatu"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// CoordinatorLeasedIteratorConfig configures how a worker leases work from
+// the Coordinator.
+type CoordinatorLeasedIteratorConfig struct {
+	// Addr is the Coordinator's gRPC endpoint, e.g. "coordinator:8080".
+	Addr string `yaml:"addr"`
+	// WorkerID uniquely identifies this worker to the coordinator across
+	// restarts, e.g. a hostname/pod name.
+	WorkerID string `yaml:"workerId"`
+	// ChunkSize is how many slots to request per lease.
+	ChunkSize uint64 `yaml:"chunkSize" default:"32"`
+	// HeartbeatInterval is how often to extend an in-progress lease's TTL.
+	HeartbeatInterval time.Duration `yaml:"heartbeatInterval" default:"15s"`
+}
+
+// CoordinatorLeasedIterator backs Iterator with slot ranges leased from the
+// central Coordinator over a LeaseCannonLocations stream, instead of a
+// locally persisted checkpoint. This lets multiple cannon replicas shard a
+// (network, cannon_type)'s backfill without overlapping.
+type CoordinatorLeasedIterator struct {
+	log        logrus.FieldLogger
+	cfg        *CoordinatorLeasedIteratorConfig
+	client     xatu.CoordinatorClient
+	network    string
+	cannonType xatu.CannonType
+
+	mu            sync.Mutex
+	stream        xatu.Coordinator_LeaseCannonLocationsClient
+	current       *xatu.LeaseResponse
+	next          uint64
+	stopHeartbeat chan struct{}
+}
+
+// NewCoordinatorLeasedIterator dials cfg.Addr, instrumented with
+// OpenTelemetry tracing so the lease stream's spans show up alongside the
+// rest of the coordinator's gRPC traffic, and returns an iterator backed by
+// that connection.
+func NewCoordinatorLeasedIterator(ctx context.Context, log logrus.FieldLogger, cfg *CoordinatorLeasedIteratorConfig, network string, cannonType xatu.CannonType) (*CoordinatorLeasedIterator, error) {
+	dialOpts := append(tracing.DialOptions(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+	conn, err := grpc.DialContext(ctx, cfg.Addr, dialOpts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial coordinator at %s", cfg.Addr)
+	}
+
+	return &CoordinatorLeasedIterator{
+		log:        log.WithField("module", "cannon/iterator/coordinator_leased"),
+		cfg:        cfg,
+		client:     xatu.NewCoordinatorClient(conn),
+		network:    network,
+		cannonType: cannonType,
+	}, nil
+}
+
+func (c *CoordinatorLeasedIterator) Next(ctx context.Context) (*xatu.CannonLocation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.next >= c.current.GetEndSlot() {
+		if err := c.leaseNextChunk(ctx); err != nil {
+			return nil, errors.Wrap(err, "failed to lease next chunk from coordinator")
+		}
+	}
+
+	location := locationForSlot(c.cannonType, c.next)
+	if location == nil {
+		return nil, fmt.Errorf("unsupported cannon type: %s", c.cannonType)
+	}
+
+	location.Reorged = c.current.GetReorged()
+
+	return location, nil
+}
+
+func (c *CoordinatorLeasedIterator) UpdateLocation(ctx context.Context, location *xatu.CannonLocation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.next++
+
+	if c.next >= c.current.GetEndSlot() {
+		if c.stopHeartbeat != nil {
+			close(c.stopHeartbeat)
+			c.stopHeartbeat = nil
+		}
+
+		if _, err := c.client.AckCannonLocation(ctx, &xatu.AckCannonLocationRequest{
+			LeaseId:  c.current.GetLeaseId(),
+			WorkerId: c.cfg.WorkerID,
+		}); err != nil {
+			return errors.Wrap(err, "failed to ack cannon location")
+		}
+	}
+
+	return nil
+}
+
+// MarkDirty reports [startSlot, endSlot] to the coordinator, so it's
+// re-handed-out - marked reorged - to the next worker that leases this
+// (network, cannon_type), instead of being trusted as canonical forever.
+func (c *CoordinatorLeasedIterator) MarkDirty(ctx context.Context, startSlot, endSlot phase0.Slot) error {
+	_, err := c.client.MarkDirty(ctx, &xatu.MarkDirtyRequest{
+		Network:    c.network,
+		CannonType: c.cannonType,
+		StartSlot:  uint64(startSlot),
+		EndSlot:    uint64(endSlot),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to mark slot range dirty")
+	}
+
+	return nil
+}
+
+func (c *CoordinatorLeasedIterator) leaseNextChunk(ctx context.Context) error {
+	if c.stream == nil {
+		stream, err := c.client.LeaseCannonLocations(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to open lease stream")
+		}
+
+		c.stream = stream
+	}
+
+	if err := c.stream.Send(&xatu.LeaseRequest{
+		Network:    c.network,
+		CannonType: c.cannonType,
+		WorkerId:   c.cfg.WorkerID,
+		ChunkSize:  c.cfg.ChunkSize,
+	}); err != nil {
+		c.stream = nil
+
+		return errors.Wrap(err, "failed to send lease request")
+	}
+
+	lease, err := c.stream.Recv()
+	if err != nil {
+		c.stream = nil
+
+		return errors.Wrap(err, "failed to receive lease response")
+	}
+
+	c.current = lease
+	c.next = lease.GetStartSlot()
+	c.stopHeartbeat = make(chan struct{})
+
+	go c.heartbeat(lease.GetLeaseId(), c.stopHeartbeat)
+
+	return nil
+}
+
+func (c *CoordinatorLeasedIterator) heartbeat(leaseID string, stop chan struct{}) {
+	ticker := time.NewTicker(c.cfg.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if _, err := c.client.HeartbeatCannonLocation(context.Background(), &xatu.HeartbeatCannonLocationRequest{
+				LeaseId:  leaseID,
+				WorkerId: c.cfg.WorkerID,
+			}); err != nil {
+				c.log.WithError(err).WithField("lease_id", leaseID).Warn("Failed to heartbeat lease")
+			}
+		}
+	}
+}
+
+func locationForSlot(cannonType xatu.CannonType, slot uint64) *xatu.CannonLocation {
+	switch cannonType {
+	case xatu.CannonType_BEACON_API_ETH_V2_BEACON_BLOCK_BLS_TO_EXECUTION_CHANGE:
+		return &xatu.CannonLocation{
+			Type: &xatu.CannonLocation_EthV2BeaconBlockBlsToExecutionChange{
+				EthV2BeaconBlockBlsToExecutionChange: &xatu.CannonLocationEthV2BeaconBlockBLSToExecutionChange{
+					Slot: slot,
+				},
+			},
+		}
+	case xatu.CannonType_BEACON_API_ETH_V1_BEACON_BLOB_SIDECAR:
+		return &xatu.CannonLocation{
+			Type: &xatu.CannonLocation_EthV1BeaconBlobSidecar{
+				EthV1BeaconBlobSidecar: &xatu.CannonLocationEthV1BeaconBlobSidecar{
+					Slot: slot,
+				},
+			},
+		}
+	default:
+		return nil
+	}
+}
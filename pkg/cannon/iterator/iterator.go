@@ -0,0 +1,26 @@
+package iterator
+
+import (
+	"context"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/xatu/pkg/proto/xatu"
+)
+
+// Iterator hands derivers the next location to process and persists
+// progress as locations are completed. SlotIterator backs it with a local
+// checkpoint; CoordinatorLeasedIterator backs it with leases handed out by
+// the central Coordinator so multiple workers can shard a (network,
+// cannon_type)'s slot range without duplicating work.
+type Iterator interface {
+	// Next returns the next location for the deriver to process, blocking
+	// until one is available.
+	Next(ctx context.Context) (*xatu.CannonLocation, error)
+	// UpdateLocation persists location as fully processed.
+	UpdateLocation(ctx context.Context, location *xatu.CannonLocation) error
+	// MarkDirty flags the (inclusive) slot range as needing re-derivation,
+	// e.g. because a reorg made the previously-fetched blocks non-canonical.
+	// A subsequent Next() re-emits locations in the range before resuming
+	// forward progress.
+	MarkDirty(ctx context.Context, startSlot, endSlot phase0.Slot) error
+}
@@ -0,0 +1,207 @@
+package iterator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/xatu/pkg/cannon/ethereum"
+	"github.com/ethpandaops/xatu/pkg/proto/xatu"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// SlotCheckpointStore persists a SlotIterator's forward checkpoint so a
+// restarting cannon resumes where it left off instead of re-deriving
+// everything from SlotIteratorConfig.StartSlot.
+type SlotCheckpointStore interface {
+	LoadSlot(ctx context.Context, network string, cannonType xatu.CannonType) (uint64, error)
+	SaveSlot(ctx context.Context, network string, cannonType xatu.CannonType, slot uint64) error
+}
+
+// SlotIteratorConfig configures a SlotIterator.
+type SlotIteratorConfig struct {
+	// StartSlot is where iteration begins the first time this
+	// (network, cannon_type) is seen, i.e. no checkpoint has been saved yet.
+	StartSlot uint64 `yaml:"startSlot"`
+}
+
+// dirtyRange is a slot range reported reorged out that hasn't finished
+// being re-emitted yet. next is the cursor within [start, end] still to
+// re-derive.
+type dirtyRange struct {
+	start, end, next phase0.Slot
+}
+
+// SlotIterator backs Iterator with a locally persisted checkpoint, rather
+// than leasing ranges from a Coordinator. It's suitable for a single cannon
+// replica per (network, cannon_type).
+//
+// When a ReorgDetector is supplied, the iterator registers itself so that a
+// reorg re-emits every slot in the affected range - marked with
+// CannonLocation.Reorged - ahead of resuming forward iteration, instead of
+// trusting a block it already derived as canonical forever.
+type SlotIterator struct {
+	log        logrus.FieldLogger
+	cfg        *SlotIteratorConfig
+	store      SlotCheckpointStore
+	network    string
+	cannonType xatu.CannonType
+
+	mu     sync.Mutex
+	loaded bool
+	next   uint64
+	dirty  []*dirtyRange
+	// currentDirty is the dirty range the most recent Next() call emitted a
+	// slot from, if any. UpdateLocation advances it by identity rather than
+	// by index, since a concurrent MarkDirty (from the ReorgDetector's own
+	// goroutine) can insert a range ahead of it and reorder s.dirty between
+	// the Next() and UpdateLocation() calls for the same slot.
+	currentDirty *dirtyRange
+}
+
+// NewSlotIterator creates a SlotIterator. store may be nil, in which case
+// the checkpoint isn't persisted across restarts. reorg may be nil, in
+// which case the iterator never re-derives reorged ranges.
+func NewSlotIterator(log logrus.FieldLogger, cfg *SlotIteratorConfig, store SlotCheckpointStore, network string, cannonType xatu.CannonType, reorg *ethereum.ReorgDetector) *SlotIterator {
+	s := &SlotIterator{
+		log:        log.WithField("module", "cannon/iterator/slot"),
+		cfg:        cfg,
+		store:      store,
+		network:    network,
+		cannonType: cannonType,
+	}
+
+	if reorg != nil {
+		reorg.OnReorg(s.MarkDirty)
+	}
+
+	return s
+}
+
+func (s *SlotIterator) Next(ctx context.Context) (*xatu.CannonLocation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loaded {
+		slot, err := s.loadLocked(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		s.next = slot
+		s.loaded = true
+	}
+
+	if len(s.dirty) > 0 {
+		d := s.dirty[0]
+
+		location := locationForSlot(s.cannonType, uint64(d.next))
+		if location == nil {
+			return nil, fmt.Errorf("unsupported cannon type: %s", s.cannonType)
+		}
+
+		location.Reorged = true
+		s.currentDirty = d
+
+		return location, nil
+	}
+
+	s.currentDirty = nil
+
+	location := locationForSlot(s.cannonType, s.next)
+	if location == nil {
+		return nil, fmt.Errorf("unsupported cannon type: %s", s.cannonType)
+	}
+
+	return location, nil
+}
+
+func (s *SlotIterator) UpdateLocation(ctx context.Context, location *xatu.CannonLocation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d := s.currentDirty; d != nil {
+		d.next++
+
+		if d.next > d.end {
+			for i, r := range s.dirty {
+				if r == d {
+					s.dirty = append(s.dirty[:i], s.dirty[i+1:]...)
+
+					break
+				}
+			}
+		}
+
+		s.currentDirty = nil
+
+		// Re-deriving a dirty range doesn't move the forward checkpoint: it
+		// was already past this range, and the range's slots were already
+		// accounted for the first time they were derived.
+		return nil
+	}
+
+	s.next++
+
+	if s.store != nil {
+		if err := s.store.SaveSlot(ctx, s.network, s.cannonType, s.next); err != nil {
+			return errors.Wrap(err, "failed to persist slot checkpoint")
+		}
+	}
+
+	return nil
+}
+
+// MarkDirty queues [startSlot, endSlot] to be re-emitted with
+// CannonLocation.Reorged set, ahead of resuming forward iteration. Slots at
+// or beyond the current forward checkpoint are dropped from the range:
+// we haven't derived them yet, so they'll be derived correctly - as
+// canonical - when we get there normally.
+func (s *SlotIterator) MarkDirty(ctx context.Context, startSlot, endSlot phase0.Slot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loaded {
+		// Nothing's been derived yet, so there's nothing to re-derive.
+		return nil
+	}
+
+	if uint64(endSlot) >= s.next {
+		if s.next == 0 {
+			return nil
+		}
+
+		endSlot = phase0.Slot(s.next - 1)
+	}
+
+	if startSlot > endSlot {
+		return nil
+	}
+
+	s.log.WithFields(logrus.Fields{
+		"start_slot": startSlot,
+		"end_slot":   endSlot,
+	}).Info("Marking slot range dirty for re-derivation")
+
+	s.dirty = append(s.dirty, &dirtyRange{start: startSlot, end: endSlot, next: startSlot})
+
+	sort.Slice(s.dirty, func(i, j int) bool { return s.dirty[i].start < s.dirty[j].start })
+
+	return nil
+}
+
+func (s *SlotIterator) loadLocked(ctx context.Context) (uint64, error) {
+	if s.store == nil {
+		return s.cfg.StartSlot, nil
+	}
+
+	slot, err := s.store.LoadSlot(ctx, s.network, s.cannonType)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to load slot checkpoint")
+	}
+
+	return slot, nil
+}
@@ -0,0 +1,14 @@
+package tracing
+
+// Config configures the OpenTelemetry tracer provider shared by the
+// coordinator server/client and the cannon derivers.
+type Config struct {
+	Enabled bool `yaml:"enabled" default:"false"`
+	// Endpoint is the OTLP gRPC collector endpoint, e.g. "localhost:4317".
+	Endpoint string `yaml:"endpoint"`
+	// SamplerRatio is the fraction of traces to sample, between 0 and 1.
+	SamplerRatio float64 `yaml:"samplerRatio" default:"1"`
+	// ServiceName identifies this process in the exported spans.
+	ServiceName string `yaml:"serviceName" default:"xatu"`
+	Insecure    bool   `yaml:"insecure" default:"false"`
+}
@@ -0,0 +1,23 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+// ServerOptions returns the grpc.ServerOptions that instrument a server
+// (e.g. xatu.Coordinator, registered via RegisterCoordinatorServer) with
+// OpenTelemetry tracing.
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor()),
+	}
+}
+
+// DialOptions returns the grpc.DialOptions that instrument a client (e.g.
+// one created via xatu.NewCoordinatorClient) with OpenTelemetry tracing.
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+	}
+}
@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// NewTracerProvider constructs an OTLP-over-gRPC tracer provider tagged with
+// the network and xatu component/module that produced the spans, and
+// registers it as the global provider so every `otel.Tracer(...)` call in
+// the process picks it up.
+func NewTracerProvider(ctx context.Context, cfg *Config, network, component, module string) (*sdktrace.TracerProvider, error) {
+	if !cfg.Enabled {
+		return sdktrace.NewTracerProvider(), nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptrace.New(ctx, otlptracegrpc.NewClient(opts...))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create otlp trace exporter")
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String(cfg.ServiceName),
+			semconv.ServiceNamespaceKey.String("xatu"),
+			attribute.String("xatu.network", network),
+			attribute.String("xatu.component", component),
+			attribute.String("xatu.module", module),
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create otel resource")
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SamplerRatio))),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return tp, nil
+}
@@ -23,6 +23,19 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CoordinatorClient interface {
 	CreateNodeRecords(ctx context.Context, in *CreateNodeRecordsRequest, opts ...grpc.CallOption) (*CreateNodeRecordsResponse, error)
+	GetNodeRecords(ctx context.Context, in *GetNodeRecordsRequest, opts ...grpc.CallOption) (*GetNodeRecordsResponse, error)
+	// LeaseCannonLocations allows a cannon worker to request non-overlapping
+	// slot ranges for a (network, cannon_type) pair, and is kept open for the
+	// lifetime of the worker so the coordinator can hand out further leases
+	// as earlier ones are acked.
+	LeaseCannonLocations(ctx context.Context, opts ...grpc.CallOption) (Coordinator_LeaseCannonLocationsClient, error)
+	// AckCannonLocation marks a leased range as fully processed.
+	AckCannonLocation(ctx context.Context, in *AckCannonLocationRequest, opts ...grpc.CallOption) (*AckCannonLocationResponse, error)
+	// HeartbeatCannonLocation extends a lease's TTL while the worker is still
+	// actively processing it.
+	HeartbeatCannonLocation(ctx context.Context, in *HeartbeatCannonLocationRequest, opts ...grpc.CallOption) (*HeartbeatCannonLocationResponse, error)
+	// MarkDirty reports that a slot range was reorged out so it's re-leased.
+	MarkDirty(ctx context.Context, in *MarkDirtyRequest, opts ...grpc.CallOption) (*MarkDirtyResponse, error)
 }
 
 type coordinatorClient struct {
@@ -42,11 +55,91 @@ func (c *coordinatorClient) CreateNodeRecords(ctx context.Context, in *CreateNod
 	return out, nil
 }
 
+func (c *coordinatorClient) GetNodeRecords(ctx context.Context, in *GetNodeRecordsRequest, opts ...grpc.CallOption) (*GetNodeRecordsResponse, error) {
+	out := new(GetNodeRecordsResponse)
+	err := c.cc.Invoke(ctx, "/xatu.Coordinator/GetNodeRecords", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorClient) LeaseCannonLocations(ctx context.Context, opts ...grpc.CallOption) (Coordinator_LeaseCannonLocationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Coordinator_ServiceDesc.Streams[0], "/xatu.Coordinator/LeaseCannonLocations", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &coordinatorLeaseCannonLocationsClient{stream}
+	return x, nil
+}
+
+type Coordinator_LeaseCannonLocationsClient interface {
+	Send(*LeaseRequest) error
+	Recv() (*LeaseResponse, error)
+	grpc.ClientStream
+}
+
+type coordinatorLeaseCannonLocationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *coordinatorLeaseCannonLocationsClient) Send(m *LeaseRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *coordinatorLeaseCannonLocationsClient) Recv() (*LeaseResponse, error) {
+	m := new(LeaseResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *coordinatorClient) AckCannonLocation(ctx context.Context, in *AckCannonLocationRequest, opts ...grpc.CallOption) (*AckCannonLocationResponse, error) {
+	out := new(AckCannonLocationResponse)
+	err := c.cc.Invoke(ctx, "/xatu.Coordinator/AckCannonLocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorClient) HeartbeatCannonLocation(ctx context.Context, in *HeartbeatCannonLocationRequest, opts ...grpc.CallOption) (*HeartbeatCannonLocationResponse, error) {
+	out := new(HeartbeatCannonLocationResponse)
+	err := c.cc.Invoke(ctx, "/xatu.Coordinator/HeartbeatCannonLocation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *coordinatorClient) MarkDirty(ctx context.Context, in *MarkDirtyRequest, opts ...grpc.CallOption) (*MarkDirtyResponse, error) {
+	out := new(MarkDirtyResponse)
+	err := c.cc.Invoke(ctx, "/xatu.Coordinator/MarkDirty", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CoordinatorServer is the server API for Coordinator service.
 // All implementations must embed UnimplementedCoordinatorServer
 // for forward compatibility
 type CoordinatorServer interface {
 	CreateNodeRecords(context.Context, *CreateNodeRecordsRequest) (*CreateNodeRecordsResponse, error)
+	GetNodeRecords(context.Context, *GetNodeRecordsRequest) (*GetNodeRecordsResponse, error)
+	// LeaseCannonLocations allows a cannon worker to request non-overlapping
+	// slot ranges for a (network, cannon_type) pair, and is kept open for the
+	// lifetime of the worker so the coordinator can hand out further leases
+	// as earlier ones are acked.
+	LeaseCannonLocations(Coordinator_LeaseCannonLocationsServer) error
+	// AckCannonLocation marks a leased range as fully processed.
+	AckCannonLocation(context.Context, *AckCannonLocationRequest) (*AckCannonLocationResponse, error)
+	// HeartbeatCannonLocation extends a lease's TTL while the worker is still
+	// actively processing it.
+	HeartbeatCannonLocation(context.Context, *HeartbeatCannonLocationRequest) (*HeartbeatCannonLocationResponse, error)
+	// MarkDirty reports that a slot range was reorged out so it's re-leased.
+	MarkDirty(context.Context, *MarkDirtyRequest) (*MarkDirtyResponse, error)
 	mustEmbedUnimplementedCoordinatorServer()
 }
 
@@ -57,6 +150,21 @@ type UnimplementedCoordinatorServer struct {
 func (UnimplementedCoordinatorServer) CreateNodeRecords(context.Context, *CreateNodeRecordsRequest) (*CreateNodeRecordsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateNodeRecords not implemented")
 }
+func (UnimplementedCoordinatorServer) GetNodeRecords(context.Context, *GetNodeRecordsRequest) (*GetNodeRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeRecords not implemented")
+}
+func (UnimplementedCoordinatorServer) LeaseCannonLocations(Coordinator_LeaseCannonLocationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method LeaseCannonLocations not implemented")
+}
+func (UnimplementedCoordinatorServer) AckCannonLocation(context.Context, *AckCannonLocationRequest) (*AckCannonLocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AckCannonLocation not implemented")
+}
+func (UnimplementedCoordinatorServer) HeartbeatCannonLocation(context.Context, *HeartbeatCannonLocationRequest) (*HeartbeatCannonLocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HeartbeatCannonLocation not implemented")
+}
+func (UnimplementedCoordinatorServer) MarkDirty(context.Context, *MarkDirtyRequest) (*MarkDirtyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkDirty not implemented")
+}
 func (UnimplementedCoordinatorServer) mustEmbedUnimplementedCoordinatorServer() {}
 
 // UnsafeCoordinatorServer may be embedded to opt out of forward compatibility for this service.
@@ -88,6 +196,104 @@ func _Coordinator_CreateNodeRecords_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Coordinator_GetNodeRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).GetNodeRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xatu.Coordinator/GetNodeRecords",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).GetNodeRecords(ctx, req.(*GetNodeRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coordinator_LeaseCannonLocations_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CoordinatorServer).LeaseCannonLocations(&coordinatorLeaseCannonLocationsServer{stream})
+}
+
+type Coordinator_LeaseCannonLocationsServer interface {
+	Send(*LeaseResponse) error
+	Recv() (*LeaseRequest, error)
+	grpc.ServerStream
+}
+
+type coordinatorLeaseCannonLocationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *coordinatorLeaseCannonLocationsServer) Send(m *LeaseResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *coordinatorLeaseCannonLocationsServer) Recv() (*LeaseRequest, error) {
+	m := new(LeaseRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Coordinator_AckCannonLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckCannonLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).AckCannonLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xatu.Coordinator/AckCannonLocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).AckCannonLocation(ctx, req.(*AckCannonLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coordinator_HeartbeatCannonLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatCannonLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).HeartbeatCannonLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xatu.Coordinator/HeartbeatCannonLocation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).HeartbeatCannonLocation(ctx, req.(*HeartbeatCannonLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coordinator_MarkDirty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkDirtyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CoordinatorServer).MarkDirty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/xatu.Coordinator/MarkDirty",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CoordinatorServer).MarkDirty(ctx, req.(*MarkDirtyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Coordinator_ServiceDesc is the grpc.ServiceDesc for Coordinator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -99,7 +305,30 @@ var Coordinator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateNodeRecords",
 			Handler:    _Coordinator_CreateNodeRecords_Handler,
 		},
+		{
+			MethodName: "GetNodeRecords",
+			Handler:    _Coordinator_GetNodeRecords_Handler,
+		},
+		{
+			MethodName: "AckCannonLocation",
+			Handler:    _Coordinator_AckCannonLocation_Handler,
+		},
+		{
+			MethodName: "HeartbeatCannonLocation",
+			Handler:    _Coordinator_HeartbeatCannonLocation_Handler,
+		},
+		{
+			MethodName: "MarkDirty",
+			Handler:    _Coordinator_MarkDirty_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "LeaseCannonLocations",
+			Handler:       _Coordinator_LeaseCannonLocations_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/proto/xatu/coordinator.proto",
-}
\ No newline at end of file
+}
@@ -0,0 +1,367 @@
+package coordinator
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/xatu/pkg/proto/xatu"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AllocatorConfig configures how the Allocator leases out cannon locations.
+type AllocatorConfig struct {
+	// LeaseTTL is how long a handed-out chunk is reserved for its worker
+	// before it's considered abandoned (no ack, no heartbeat) and handed
+	// out again to the next worker that asks.
+	LeaseTTL time.Duration `yaml:"leaseTTL" default:"1m"`
+}
+
+type progressKey struct {
+	network    string
+	cannonType xatu.CannonType
+}
+
+// chunk is a [startSlot, endSlot) slot range, either outstanding with a
+// worker or waiting in a key's requeue after its lease expired.
+type chunk struct {
+	leaseID    string
+	network    string
+	cannonType xatu.CannonType
+	workerID   string
+	startSlot  uint64
+	endSlot    uint64
+	expiresAt  time.Time
+	// reorged is true if this chunk was already processed once but is being
+	// requeued because MarkDirty reported it was reorged out.
+	reorged bool
+}
+
+// Allocator implements xatu.CoordinatorServer: it hands out non-overlapping,
+// TTL'd slot ranges per (network, cannon_type) and persists progress as
+// workers ack them, so restarting the coordinator or losing a worker never
+// duplicates or skips a range.
+type Allocator struct {
+	xatu.UnimplementedCoordinatorServer
+
+	log   logrus.FieldLogger
+	cfg   *AllocatorConfig
+	store Store
+
+	mu sync.Mutex
+	// watermark is the next slot not yet handed out, per key. It only moves
+	// forward as chunks are leased.
+	watermark map[progressKey]uint64
+	// leases holds every chunk currently out with a worker, keyed by lease ID.
+	leases map[string]*chunk
+	// requeued holds expired chunks waiting to be handed out again, per key,
+	// ordered by start slot.
+	requeued map[progressKey][]*chunk
+}
+
+// NewAllocator creates an Allocator. store may be nil, in which case
+// progress isn't persisted across restarts.
+func NewAllocator(log logrus.FieldLogger, cfg *AllocatorConfig, store Store) *Allocator {
+	return &Allocator{
+		log:       log.WithField("module", "server/coordinator/allocator"),
+		cfg:       cfg,
+		store:     store,
+		watermark: make(map[progressKey]uint64),
+		leases:    make(map[string]*chunk),
+		requeued:  make(map[progressKey][]*chunk),
+	}
+}
+
+func (a *Allocator) CreateNodeRecords(ctx context.Context, req *xatu.CreateNodeRecordsRequest) (*xatu.CreateNodeRecordsResponse, error) {
+	if a.store == nil {
+		return &xatu.CreateNodeRecordsResponse{}, nil
+	}
+
+	if err := a.store.SaveNodeRecords(ctx, req.GetNodeRecords()); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save node records: %v", err)
+	}
+
+	return &xatu.CreateNodeRecordsResponse{}, nil
+}
+
+func (a *Allocator) GetNodeRecords(ctx context.Context, req *xatu.GetNodeRecordsRequest) (*xatu.GetNodeRecordsResponse, error) {
+	if a.store == nil {
+		return &xatu.GetNodeRecordsResponse{}, nil
+	}
+
+	records, err := a.store.GetNodeRecords(ctx, req.GetNetwork(), req.GetLimit())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get node records: %v", err)
+	}
+
+	return &xatu.GetNodeRecordsResponse{NodeRecords: records}, nil
+}
+
+// LeaseCannonLocations serves lease requests for the lifetime of the stream,
+// replying to each with a freshly leased chunk before waiting for the next
+// request.
+func (a *Allocator) LeaseCannonLocations(stream xatu.Coordinator_LeaseCannonLocationsServer) error {
+	ctx := stream.Context()
+
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		resp, err := a.leaseChunk(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (a *Allocator) leaseChunk(ctx context.Context, req *xatu.LeaseRequest) (*xatu.LeaseResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.expireLocked()
+
+	key := progressKey{network: req.GetNetwork(), cannonType: req.GetCannonType()}
+
+	c, err := a.nextChunkLocked(ctx, key, req.GetWorkerId(), req.GetChunkSize())
+	if err != nil {
+		return nil, err
+	}
+
+	a.leases[c.leaseID] = c
+
+	return &xatu.LeaseResponse{
+		Network:    c.network,
+		CannonType: c.cannonType,
+		StartSlot:  c.startSlot,
+		EndSlot:    c.endSlot,
+		LeaseId:    c.leaseID,
+		ExpiresAt:  c.expiresAt.Unix(),
+		Reorged:    c.reorged,
+	}, nil
+}
+
+// nextChunkLocked returns the next chunk to hand out for key: a previously
+// expired, requeued range if one's waiting, otherwise a fresh range off the
+// forward watermark. Must be called with a.mu held.
+func (a *Allocator) nextChunkLocked(ctx context.Context, key progressKey, workerID string, chunkSize uint64) (*chunk, error) {
+	if pending := a.requeued[key]; len(pending) > 0 {
+		c := pending[0]
+		a.requeued[key] = pending[1:]
+
+		c.leaseID = uuid.New().String()
+		c.workerID = workerID
+		c.expiresAt = time.Now().Add(a.cfg.LeaseTTL)
+
+		return c, nil
+	}
+
+	start, ok := a.watermark[key]
+	if !ok {
+		loaded, err := a.loadProgressLocked(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		start = loaded
+	}
+
+	if chunkSize == 0 {
+		chunkSize = 1
+	}
+
+	c := &chunk{
+		leaseID:    uuid.New().String(),
+		network:    key.network,
+		cannonType: key.cannonType,
+		workerID:   workerID,
+		startSlot:  start,
+		endSlot:    start + chunkSize,
+		expiresAt:  time.Now().Add(a.cfg.LeaseTTL),
+	}
+
+	a.watermark[key] = c.endSlot
+
+	return c, nil
+}
+
+func (a *Allocator) loadProgressLocked(ctx context.Context, key progressKey) (uint64, error) {
+	if a.store == nil {
+		return 0, nil
+	}
+
+	progress, err := a.store.LoadProgress(ctx, key.network, key.cannonType)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to load progress for %s/%s: %v", key.network, key.cannonType, err)
+	}
+
+	return progress, nil
+}
+
+// AckCannonLocation marks a leased chunk as fully processed, and persists
+// the new progress watermark: the start of the earliest chunk still
+// outstanding for that key, or the forward watermark if none remain.
+func (a *Allocator) AckCannonLocation(ctx context.Context, req *xatu.AckCannonLocationRequest) (*xatu.AckCannonLocationResponse, error) {
+	a.mu.Lock()
+
+	c, ok := a.leases[req.GetLeaseId()]
+	if !ok || c.workerID != req.GetWorkerId() {
+		a.mu.Unlock()
+
+		return nil, status.Errorf(codes.NotFound, "lease %s not held by worker %s", req.GetLeaseId(), req.GetWorkerId())
+	}
+
+	delete(a.leases, req.GetLeaseId())
+
+	key := progressKey{network: c.network, cannonType: c.cannonType}
+	safe := a.lowWatermarkLocked(key)
+
+	a.mu.Unlock()
+
+	if a.store != nil {
+		if err := a.store.SaveProgress(ctx, c.network, c.cannonType, c.workerID, safe); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to save progress: %v", err)
+		}
+	}
+
+	return &xatu.AckCannonLocationResponse{}, nil
+}
+
+// lowWatermarkLocked returns the lowest start slot across every chunk still
+// outstanding (leased or requeued) for key, or the forward watermark if
+// nothing is outstanding. This is the slot up to which every range is
+// durably known to be processed. Must be called with a.mu held.
+func (a *Allocator) lowWatermarkLocked(key progressKey) uint64 {
+	safe := a.watermark[key]
+
+	for _, c := range a.leases {
+		if c.network == key.network && c.cannonType == key.cannonType && c.startSlot < safe {
+			safe = c.startSlot
+		}
+	}
+
+	for _, c := range a.requeued[key] {
+		if c.startSlot < safe {
+			safe = c.startSlot
+		}
+	}
+
+	return safe
+}
+
+// HeartbeatCannonLocation extends a lease's TTL so a worker still actively
+// processing a chunk doesn't have it expired and reassigned out from under it.
+func (a *Allocator) HeartbeatCannonLocation(ctx context.Context, req *xatu.HeartbeatCannonLocationRequest) (*xatu.HeartbeatCannonLocationResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	c, ok := a.leases[req.GetLeaseId()]
+	if !ok || c.workerID != req.GetWorkerId() {
+		return nil, status.Errorf(codes.NotFound, "lease %s not held by worker %s", req.GetLeaseId(), req.GetWorkerId())
+	}
+
+	c.expiresAt = time.Now().Add(a.cfg.LeaseTTL)
+
+	return &xatu.HeartbeatCannonLocationResponse{ExpiresAt: c.expiresAt.Unix()}, nil
+}
+
+// MarkDirty reports that [start_slot, end_slot] was reorged out for a
+// (network, cannon_type), so it's re-handed-out - marked reorged - to the
+// next worker that leases that key, instead of being trusted as canonical
+// forever. The range is clipped to the part already handed out at least
+// once (below the forward watermark): slots beyond it haven't been derived
+// yet, so they'll be derived correctly, as canonical, when reached normally.
+func (a *Allocator) MarkDirty(ctx context.Context, req *xatu.MarkDirtyRequest) (*xatu.MarkDirtyResponse, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := progressKey{network: req.GetNetwork(), cannonType: req.GetCannonType()}
+
+	startSlot, endSlot := req.GetStartSlot(), req.GetEndSlot()
+
+	watermark, ok := a.watermark[key]
+	if !ok {
+		// Nothing's been leased for this key yet, so there's nothing to
+		// re-derive.
+		return &xatu.MarkDirtyResponse{}, nil
+	}
+
+	if endSlot >= watermark {
+		if watermark == 0 {
+			return &xatu.MarkDirtyResponse{}, nil
+		}
+
+		endSlot = watermark - 1
+	}
+
+	if startSlot > endSlot {
+		return &xatu.MarkDirtyResponse{}, nil
+	}
+
+	a.log.WithFields(logrus.Fields{
+		"network":     key.network,
+		"cannon_type": key.cannonType,
+		"start_slot":  startSlot,
+		"end_slot":    endSlot,
+	}).Info("Marking slot range dirty for re-lease")
+
+	a.requeued[key] = append(a.requeued[key], &chunk{
+		network:    key.network,
+		cannonType: key.cannonType,
+		startSlot:  startSlot,
+		endSlot:    endSlot + 1,
+		reorged:    true,
+	})
+
+	sort.Slice(a.requeued[key], func(i, j int) bool { return a.requeued[key][i].startSlot < a.requeued[key][j].startSlot })
+
+	return &xatu.MarkDirtyResponse{}, nil
+}
+
+// expireLocked moves every chunk whose TTL has passed without a heartbeat or
+// ack into its key's requeue, so it's handed out to the next worker that
+// leases that (network, cannon_type) instead of being lost. Must be called
+// with a.mu held.
+func (a *Allocator) expireLocked() {
+	now := time.Now()
+
+	for leaseID, c := range a.leases {
+		if now.Before(c.expiresAt) {
+			continue
+		}
+
+		a.log.WithFields(logrus.Fields{
+			"lease_id":    leaseID,
+			"network":     c.network,
+			"cannon_type": c.cannonType,
+			"worker_id":   c.workerID,
+			"start_slot":  c.startSlot,
+			"end_slot":    c.endSlot,
+		}).Warn("Lease expired without ack or heartbeat, reassigning")
+
+		delete(a.leases, leaseID)
+
+		key := progressKey{network: c.network, cannonType: c.cannonType}
+		a.requeued[key] = append(a.requeued[key], c)
+	}
+
+	for key, pending := range a.requeued {
+		sort.Slice(pending, func(i, j int) bool { return pending[i].startSlot < pending[j].startSlot })
+		a.requeued[key] = pending
+	}
+}
@@ -0,0 +1,17 @@
+package coordinator
+
+import "github.com/ethpandaops/xatu/pkg/observability/tracing"
+
+// Config configures the coordinator's gRPC and HTTP/JSON (grpc-gateway)
+// listeners.
+type Config struct {
+	// Addr is the gRPC listen address, e.g. ":8080".
+	Addr string `yaml:"addr" default:":8080"`
+	// HTTP configures the grpc-gateway REST front for Addr. Leave Addr
+	// empty to disable it.
+	HTTP struct {
+		Addr string `yaml:"addr"`
+	} `yaml:"http"`
+
+	Tracing tracing.Config `yaml:"tracing"`
+}
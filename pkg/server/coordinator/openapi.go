@@ -0,0 +1,14 @@
+package coordinator
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:embed coordinator.swagger.json
+var openAPISpec []byte
+
+func serveOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(openAPISpec)
+}
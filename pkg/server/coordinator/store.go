@@ -0,0 +1,25 @@
+package coordinator
+
+import (
+	"context"
+
+	"github.com/ethpandaops/xatu/pkg/proto/xatu"
+)
+
+// Store persists the state the Allocator needs to survive a restart: the
+// discovered node records, and the durable, gapless progress watermark per
+// (network, cannon_type) that new leases resume from.
+type Store interface {
+	SaveNodeRecords(ctx context.Context, records []string) error
+	GetNodeRecords(ctx context.Context, network string, limit uint32) ([]string, error)
+
+	// LoadProgress returns the next slot that hasn't been durably confirmed
+	// as processed for (network, cannon_type), or 0 if it's never been seen.
+	LoadProgress(ctx context.Context, network string, cannonType xatu.CannonType) (uint64, error)
+	// SaveProgress persists the next slot that hasn't been durably confirmed
+	// as processed for (network, cannon_type). Called with the low
+	// watermark across all outstanding leases, not the high watermark of
+	// what's been handed out, so a restart never skips a range that was
+	// leased but never acked.
+	SaveProgress(ctx context.Context, network string, cannonType xatu.CannonType, workerID string, nextSlot uint64) error
+}